@@ -0,0 +1,105 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScheduledSparkApplication represents a SparkApplication that is submitted on a cron schedule.
+type ScheduledSparkApplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ScheduledSparkApplicationSpec   `json:"spec"`
+	Status            ScheduledSparkApplicationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScheduledSparkApplicationList carries a list of ScheduledSparkApplication objects.
+type ScheduledSparkApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScheduledSparkApplication `json:"items,omitempty"`
+}
+
+// ConcurrencyPolicy describes how the operator treats a scheduled run that is due while a
+// previous run of the same ScheduledSparkApplication is still active.
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyAllow allows ScheduledSparkApplications to run concurrently.
+	ConcurrencyAllow ConcurrencyPolicy = "Allow"
+	// ConcurrencyForbid forbids concurrent runs. A run that comes due while the previous run hasn't
+	// finished is not skipped: it is retried with backoff and created once the previous run has
+	// finished.
+	ConcurrencyForbid ConcurrencyPolicy = "Forbid"
+	// ConcurrencyReplace kills the currently running SparkApplication and replaces it with a new one.
+	ConcurrencyReplace ConcurrencyPolicy = "Replace"
+)
+
+// ScheduleState represents the scheduling state of a ScheduledSparkApplication.
+type ScheduleState string
+
+const (
+	// FailedValidationState means the Spec of a ScheduledSparkApplication failed validation, e.g.
+	// because the cron Schedule could not be parsed.
+	FailedValidationState ScheduleState = "FailedValidation"
+	// ScheduledState means a ScheduledSparkApplication has a valid Spec and is being scheduled.
+	ScheduledState ScheduleState = "Scheduled"
+)
+
+// ScheduledSparkApplicationSpec describes the desired state of a ScheduledSparkApplication.
+type ScheduledSparkApplicationSpec struct {
+	// Schedule is a cron schedule on which the application should run.
+	Schedule string `json:"schedule"`
+	// Template is the template of the SparkApplication to be created on each run.
+	Template SparkApplicationSpec `json:"template"`
+	// Suspend is a flag telling the controller to suspend subsequent runs of the application if set
+	// to true. Defaults to false.
+	Suspend *bool `json:"suspend,omitempty"`
+	// ConcurrencyPolicy is the policy governing concurrent runs of the application.
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// SuccessfulRunHistoryLimit is the number of past successful runs to keep.
+	SuccessfulRunHistoryLimit *int32 `json:"successfulRunHistoryLimit,omitempty"`
+	// FailedRunHistoryLimit is the number of past failed runs to keep.
+	FailedRunHistoryLimit *int32 `json:"failedRunHistoryLimit,omitempty"`
+}
+
+// ScheduledSparkApplicationStatus describes the observed state of a ScheduledSparkApplication.
+type ScheduledSparkApplicationStatus struct {
+	// LastRun is the time when the last run of the application was scheduled.
+	LastRun metav1.Time `json:"lastRun,omitempty"`
+	// NextRun is the time when the next run of the application will be scheduled.
+	NextRun metav1.Time `json:"nextRun,omitempty"`
+	// LastRunName is the name of the SparkApplication created for the last run.
+	LastRunName string `json:"lastRunName,omitempty"`
+	// PastSuccessfulRunNames keeps the names of SparkApplications for past successful runs, up to
+	// SuccessfulRunHistoryLimit.
+	PastSuccessfulRunNames []string `json:"pastSuccessfulRunNames,omitempty"`
+	// PastFailedRunNames keeps the names of SparkApplications for past failed runs, up to
+	// FailedRunHistoryLimit.
+	PastFailedRunNames []string `json:"pastFailedRunNames,omitempty"`
+	// ScheduleState is the current scheduling state of the application.
+	ScheduleState ScheduleState `json:"scheduleState,omitempty"`
+	// Reason explains why the application is in the current ScheduleState, e.g. a Schedule parse
+	// error.
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2017 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SparkApplication represents a Spark application running on and using Kubernetes as a cluster
+// manager.
+type SparkApplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              SparkApplicationSpec   `json:"spec"`
+	Status            SparkApplicationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SparkApplicationList carries a list of SparkApplication objects.
+type SparkApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SparkApplication `json:"items,omitempty"`
+}
+
+// RestartPolicy describes whether and how the operator should restart a SparkApplication when it
+// terminates.
+type RestartPolicy string
+
+const (
+	// Undefined means a SparkApplication has not had a RestartPolicy set, and is treated the same
+	// as Never.
+	Undefined RestartPolicy = ""
+	// Never means the operator never restarts a terminated SparkApplication.
+	Never RestartPolicy = "Never"
+	// OnFailure means the operator restarts a SparkApplication that terminated with FailedState.
+	OnFailure RestartPolicy = "OnFailure"
+	// Always means the operator always restarts a terminated SparkApplication, regardless of its
+	// termination state.
+	Always RestartPolicy = "Always"
+)
+
+// ApplicationStateType represents the state of a SparkApplication.
+type ApplicationStateType string
+
+const (
+	NewState              ApplicationStateType = ""
+	SubmittedState        ApplicationStateType = "SUBMITTED"
+	RunningState          ApplicationStateType = "RUNNING"
+	CompletedState        ApplicationStateType = "COMPLETED"
+	FailedState           ApplicationStateType = "FAILED"
+	FailedSubmissionState ApplicationStateType = "SUBMISSION_FAILED"
+	UnknownState          ApplicationStateType = "UNKNOWN"
+)
+
+// ApplicationState records the state of a SparkApplication along with an optional error message
+// describing why the application is in a failed state.
+type ApplicationState struct {
+	State        ApplicationStateType `json:"state"`
+	ErrorMessage string               `json:"errorMessage,omitempty"`
+}
+
+// ExecutorState represents the state of an executor pod.
+type ExecutorState string
+
+const (
+	ExecutorPendingState   ExecutorState = "PENDING"
+	ExecutorRunningState   ExecutorState = "RUNNING"
+	ExecutorCompletedState ExecutorState = "COMPLETED"
+	ExecutorFailedState    ExecutorState = "FAILED"
+	ExecutorUnknownState   ExecutorState = "UNKNOWN"
+)
+
+// DeployMode describes the type of deployment of a Spark application.
+type DeployMode string
+
+const (
+	ClusterMode DeployMode = "cluster"
+	ClientMode  DeployMode = "client"
+)
+
+// SparkApplicationSpec describes the desired state of a Spark application.
+type SparkApplicationSpec struct {
+	// Type tells the type of the Spark application, i.e. Scala/Java or Python.
+	Type string `json:"type"`
+	// Mode is the deployment mode of the Spark application.
+	Mode DeployMode `json:"mode"`
+	// Image is the container image for the driver, executor, and init-container.
+	Image *string `json:"image,omitempty"`
+	// MainClass is the fully-qualified main class of the Spark application, for Scala/Java
+	// applications.
+	MainClass *string `json:"mainClass,omitempty"`
+	// MainApplicationFile is the path to a bundled jar, Python, or R file of the application.
+	MainApplicationFile *string `json:"mainApplicationFile,omitempty"`
+	// Arguments are the application arguments passed to the main class.
+	Arguments []string `json:"arguments,omitempty"`
+	// SparkConf carries user-specified Spark configuration properties.
+	SparkConf map[string]string `json:"sparkConf,omitempty"`
+	// Driver holds the configuration for the driver pod.
+	Driver DriverSpec `json:"driver"`
+	// Executor holds the configuration for the executor pods.
+	Executor ExecutorSpec `json:"executor"`
+	// RestartPolicy defines whether and how the operator should restart the SparkApplication when
+	// it terminates.
+	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty"`
+	// SubmissionByUser indicates the application was already submitted externally, so the operator
+	// should track it without invoking spark-submit itself.
+	SubmissionByUser bool `json:"submissionByUser,omitempty"`
+	// MetricsLabels are extra key-value pairs to attach to this application's Prometheus metrics,
+	// restricted by the controller's configured label allowlist to keep metric cardinality bounded.
+	MetricsLabels map[string]string `json:"metricsLabels,omitempty"`
+	// BatchScheduler is the name of the batch scheduler, e.g. "volcano", used to gang-schedule
+	// this application's driver and executor pods. Leave unset to disable gang scheduling.
+	BatchScheduler *string `json:"batchScheduler,omitempty"`
+	// BatchSchedulerOptions configures the selected BatchScheduler.
+	BatchSchedulerOptions *BatchSchedulerOptions `json:"batchSchedulerOptions,omitempty"`
+}
+
+// BatchSchedulerOptions holds the configuration passed to a SparkApplication's selected batch
+// scheduler.
+type BatchSchedulerOptions struct {
+	// Queue is the scheduling queue the application's PodGroup is submitted to.
+	Queue string `json:"queue,omitempty"`
+	// PriorityClassName is the PriorityClass assigned to the application's PodGroup.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// Resources is the minimum set of resources the scheduler must reserve before admitting the
+	// application's driver and executor pods.
+	Resources apiv1.ResourceList `json:"resources,omitempty"`
+}
+
+// SparkPodSpec holds configuration common to both the driver and executor pods.
+type SparkPodSpec struct {
+	// Cores is the number of CPU cores to request for the pod.
+	Cores *int32 `json:"cores,omitempty"`
+	// Memory is the amount of memory to request for the pod.
+	Memory *string `json:"memory,omitempty"`
+	// Labels are the Kubernetes labels to add to the pod.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are the Kubernetes annotations to add to the pod.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Volumes is the list of Kubernetes volumes the webhook adds to the pod spec in addition to the
+	// ones Spark adds itself. The webhook adds no VolumeMounts for them.
+	Volumes []apiv1.Volume `json:"volumes,omitempty"`
+	// Tolerations specifies the tolerations applied to the pod.
+	Tolerations []apiv1.Toleration `json:"tolerations,omitempty"`
+	// Sidecars is a list of sidecar containers the webhook injects into the pod alongside the
+	// Spark container.
+	Sidecars []apiv1.Container `json:"sidecars,omitempty"`
+}
+
+// DriverSpec describes the driver pod configuration.
+type DriverSpec struct {
+	SparkPodSpec `json:",inline"`
+	// NodeSelector is the Kubernetes node selector to place the driver pod on.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity specifies the scheduling affinity/anti-affinity rules for the driver pod.
+	Affinity *apiv1.Affinity `json:"affinity,omitempty"`
+}
+
+// ExecutorSpec describes the executor pod configuration.
+type ExecutorSpec struct {
+	SparkPodSpec `json:",inline"`
+	// Instances is the number of executor instances to request.
+	Instances *int32 `json:"instances,omitempty"`
+	// NodeSelector is the Kubernetes node selector to place executor pods on.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity specifies the scheduling affinity/anti-affinity rules for executor pods.
+	Affinity *apiv1.Affinity `json:"affinity,omitempty"`
+}
+
+// DriverInfo records information about the driver of a SparkApplication observed by the operator.
+type DriverInfo struct {
+	PodName      string `json:"podName,omitempty"`
+	WebUIAddress string `json:"webUIAddress,omitempty"`
+	WebUIPort    int32  `json:"webUIPort,omitempty"`
+	// WebUIIngressAddress is the externally reachable URL of the Spark UI Ingress, set only when
+	// the controller is configured with an --ingress-url-format.
+	WebUIIngressAddress string `json:"webUIIngressAddress,omitempty"`
+}
+
+// SparkApplicationStatus describes the observed state of a SparkApplication.
+type SparkApplicationStatus struct {
+	// AppID is the application ID assigned by the operator at submission time.
+	AppID string `json:"appId,omitempty"`
+	// AppState records the overall state of the application.
+	AppState ApplicationState `json:"applicationState,omitempty"`
+	// DriverInfo carries information about the driver pod.
+	DriverInfo DriverInfo `json:"driverInfo,omitempty"`
+	// ExecutorState records the state of each executor keyed by pod name.
+	ExecutorState map[string]ExecutorState `json:"executorState,omitempty"`
+	// SubmissionTime is the time the application was submitted.
+	SubmissionTime metav1.Time `json:"submissionTime,omitempty"`
+	// CompletionTime is the time the application terminated.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+}
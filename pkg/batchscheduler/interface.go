@@ -0,0 +1,33 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package batchscheduler lets the controller hand a SparkApplication's driver and executor pods
+// off to a gang scheduler before submission, so they're admitted together instead of piecemeal.
+package batchscheduler
+
+import "k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+
+// Interface is implemented by a pluggable batch/gang scheduler integration.
+type Interface interface {
+	// Name returns the name this scheduler is selected by via Spec.BatchScheduler.
+	Name() string
+	// DoBatchSchedulingOnSubmission runs before spark-submit is invoked for app. It may create
+	// external scheduling resources (e.g. a PodGroup) and annotate app's driver and executor
+	// SparkPodSpecs so the resulting pods are recognized as a single gang by the scheduler.
+	DoBatchSchedulingOnSubmission(app *v1alpha1.SparkApplication) error
+	// CleanupOnCompletion removes any resources DoBatchSchedulingOnSubmission created for app.
+	CleanupOnCompletion(app *v1alpha1.SparkApplication) error
+}
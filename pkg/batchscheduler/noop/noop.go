@@ -0,0 +1,45 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package noop provides the default batchscheduler.Interface used when a SparkApplication does
+// not opt into gang scheduling.
+package noop
+
+import "k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+
+// SchedulerName is the name the no-op scheduler is registered under and the implicit default
+// when SparkApplication.Spec.BatchScheduler is unset.
+const SchedulerName = ""
+
+// batchScheduler is a no-op batchscheduler.Interface.
+type batchScheduler struct{}
+
+// New creates a no-op batch scheduler.
+func New() *batchScheduler {
+	return &batchScheduler{}
+}
+
+func (s *batchScheduler) Name() string {
+	return SchedulerName
+}
+
+func (s *batchScheduler) DoBatchSchedulingOnSubmission(app *v1alpha1.SparkApplication) error {
+	return nil
+}
+
+func (s *batchScheduler) CleanupOnCompletion(app *v1alpha1.SparkApplication) error {
+	return nil
+}
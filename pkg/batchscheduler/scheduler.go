@@ -0,0 +1,49 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchscheduler
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/rest"
+
+	"k8s.io/spark-on-k8s-operator/pkg/batchscheduler/noop"
+	"k8s.io/spark-on-k8s-operator/pkg/batchscheduler/volcano"
+)
+
+// factories maps a scheduler name, as set in SparkApplication.Spec.BatchScheduler, to a
+// constructor for it. The empty name is the no-op scheduler, used when gang scheduling isn't
+// requested.
+var factories = map[string]func(*rest.Config) (Interface, error){
+	noop.SchedulerName: func(*rest.Config) (Interface, error) {
+		return noop.New(), nil
+	},
+	volcano.SchedulerName: func(config *rest.Config) (Interface, error) {
+		return volcano.New(config)
+	},
+}
+
+// ForName constructs the batch scheduler registered under name, using config to talk to the
+// scheduler's API extensions. An unrecognized name is an error rather than a silent fallback, so
+// a typo in Spec.BatchScheduler surfaces instead of pods submitting unganged.
+func ForName(name string, config *rest.Config) (Interface, error) {
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("no batch scheduler registered under name %q", name)
+	}
+	return factory(config)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package volcano integrates the controller with the Volcano (https://volcano.sh) batch
+// scheduler for gang scheduling of a SparkApplication's driver and executor pods.
+package volcano
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	schedulingv1beta1 "volcano.sh/volcano/pkg/apis/scheduling/v1beta1"
+	volcanoclient "volcano.sh/volcano/pkg/client/clientset/versioned"
+
+	"k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+)
+
+// SchedulerName is the name SparkApplication.Spec.BatchScheduler must be set to in order to
+// select this scheduler.
+const SchedulerName = "volcano"
+
+// podGroupNameSuffix is appended to a SparkApplication's AppID to name its PodGroup.
+const podGroupNameSuffix = "-pg"
+
+// podGroupAnnotation is set on the driver and executor SparkPodSpecs to bind the resulting pods
+// to their PodGroup.
+const podGroupAnnotation = "scheduling.k8s.io/group-name"
+
+// batchScheduler is a batchscheduler.Interface backed by Volcano PodGroups.
+type batchScheduler struct {
+	volcanoClient volcanoclient.Interface
+}
+
+// New creates a Volcano-backed batch scheduler using the given in-cluster REST config.
+func New(config *rest.Config) (*batchScheduler, error) {
+	volcanoClient, err := volcanoclient.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a Volcano client: %v", err)
+	}
+	return &batchScheduler{volcanoClient: volcanoClient}, nil
+}
+
+func (s *batchScheduler) Name() string {
+	return SchedulerName
+}
+
+// DoBatchSchedulingOnSubmission creates a PodGroup sized to admit the driver and all executors
+// together, then annotates app's driver and executor SparkPodSpecs so the pods spark-submit
+// creates are bound to it.
+func (s *batchScheduler) DoBatchSchedulingOnSubmission(app *v1alpha1.SparkApplication) error {
+	name := podGroupName(app)
+	var minMember int32 = 1
+	if app.Spec.Executor.Instances != nil {
+		minMember += *app.Spec.Executor.Instances
+	}
+
+	podGroup := &schedulingv1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(app, v1alpha1.SchemeGroupVersion.WithKind("SparkApplication")),
+			},
+		},
+		Spec: schedulingv1beta1.PodGroupSpec{
+			MinMember: minMember,
+		},
+	}
+
+	if opts := app.Spec.BatchSchedulerOptions; opts != nil {
+		podGroup.Spec.Queue = opts.Queue
+		podGroup.Spec.PriorityClassName = opts.PriorityClassName
+		if opts.Resources != nil {
+			podGroup.Spec.MinResources = &opts.Resources
+		}
+	}
+
+	if _, err := s.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Create(podGroup); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create PodGroup %s: %v", name, err)
+	}
+
+	annotate(&app.Spec.Driver.SparkPodSpec, name)
+	annotate(&app.Spec.Executor.SparkPodSpec, name)
+
+	return nil
+}
+
+// CleanupOnCompletion deletes the PodGroup created for app.
+func (s *batchScheduler) CleanupOnCompletion(app *v1alpha1.SparkApplication) error {
+	name := podGroupName(app)
+	if err := s.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Delete(name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete PodGroup %s: %v", name, err)
+	}
+	return nil
+}
+
+func podGroupName(app *v1alpha1.SparkApplication) string {
+	return app.Status.AppID + podGroupNameSuffix
+}
+
+func annotate(podSpec *v1alpha1.SparkPodSpec, podGroupName string) {
+	if podSpec.Annotations == nil {
+		podSpec.Annotations = make(map[string]string)
+	}
+	podSpec.Annotations[podGroupAnnotation] = podGroupName
+}
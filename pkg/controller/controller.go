@@ -17,27 +17,42 @@ limitations under the License.
 package controller
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sync"
 	"time"
 
 	"github.com/golang/glog"
+	"golang.org/x/time/rate"
 	"k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+	"k8s.io/spark-on-k8s-operator/pkg/batchscheduler"
+	"k8s.io/spark-on-k8s-operator/pkg/controller/metrics"
 	"k8s.io/spark-on-k8s-operator/pkg/crd"
 	"k8s.io/spark-on-k8s-operator/pkg/util"
 	crdclientset "k8s.io/spark-on-k8s-operator/pkg/client/clientset/versioned"
 	crdinformers "k8s.io/spark-on-k8s-operator/pkg/client/informers/externalversions"
+	crdlisters "k8s.io/spark-on-k8s-operator/pkg/client/listers/sparkoperator.k8s.io/v1alpha1"
 
 	apiv1 "k8s.io/api/core/v1"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	kubecontrollermetrics "k8s.io/kubernetes/pkg/util/metrics"
 	"strconv"
+
+	"k8s.io/spark-on-k8s-operator/pkg/webhook"
 )
 
 const (
@@ -45,7 +60,12 @@ const (
 	sparkDriverRole      = "driver"
 	sparkExecutorRole    = "executor"
 	sparkExecutorIDLabel = "spark-exec-id"
-	maximumUpdateRetries = 3
+	queueTokenRefillRate = 25
+	queueTokenBucketSize = 500
+	// sparkAppSpecHashAnnotation records the hash of the SparkApplication Spec that a driver pod
+	// was submitted with, so the controller can detect spec drift after a restart without relying
+	// on its in-memory runningApps cache.
+	sparkAppSpecHashAnnotation = "sparkoperator.k8s.io/spec-hash"
 )
 
 // SparkApplicationController manages instances of SparkApplication.
@@ -59,8 +79,28 @@ type SparkApplicationController struct {
 	appStateReportingChan      <-chan appStateUpdate
 	driverStateReportingChan   <-chan driverStateUpdate
 	executorStateReportingChan <-chan executorStateUpdate
-	mutex                      sync.Mutex                            // Guard SparkApplication updates to the API server and runningApps.
-	runningApps                map[string]*v1alpha1.SparkApplication // Guarded by mutex.
+	lister                     crdlisters.SparkApplicationLister
+	cacheSynced                cache.InformerSynced
+	queue                      workqueue.RateLimitingInterface
+	syncWorkers                int
+	resyncInterval             time.Duration
+	statusUpdater              ApplicationStatusUpdater
+	enableWebhook              bool
+	webhookServer              *webhook.WebhookServer
+	metrics                    *metrics.SparkAppMetrics
+	ingressURLFormat           string
+	ingressClassName           string
+	kubeConfig                 *rest.Config
+	// mutex guards runningApps. The workqueue only serializes reconciliation of a single key, but
+	// runningApps is also read and written from the submission runner's and driver/executor pod
+	// monitor's reporting goroutines (processSingleAppStateUpdate,
+	// processSingleDriverStateUpdate, processSingleExecutorStateUpdate), none of which the queue
+	// serializes against each other or against a key's own worker. So the coarse mutex from
+	// before the workqueue refactor stays, deliberately, as the thing that actually protects
+	// runningApps; the queue only gets us parallelism across distinct SparkApplications in
+	// syncSparkApplication/reconcile/submitApp.
+	mutex       sync.Mutex
+	runningApps map[string]*v1alpha1.SparkApplication // Guarded by mutex.
 }
 
 // New creates a new SparkApplicationController.
@@ -68,7 +108,14 @@ func New(
 	crdClient crdclientset.Interface,
 	kubeClient clientset.Interface,
 	extensionsClient apiextensionsclient.Interface,
-	submissionRunnerWorkers int) *SparkApplicationController {
+	submissionRunnerWorkers int,
+	resyncInterval time.Duration,
+	enableWebhook bool,
+	webhookConfig webhook.Config,
+	metricsLabels []string,
+	ingressURLFormat string,
+	ingressClassName string,
+	kubeConfig *rest.Config) *SparkApplicationController {
 	v1alpha1.AddToScheme(scheme.Scheme)
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartLogging(glog.V(2).Infof)
@@ -77,7 +124,9 @@ func New(
 	})
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, apiv1.EventSource{Component: "spark-operator"})
 
-	return newSparkApplicationController(crdClient, kubeClient, extensionsClient, recorder, submissionRunnerWorkers)
+	return newSparkApplicationController(
+		crdClient, kubeClient, extensionsClient, recorder, submissionRunnerWorkers, resyncInterval,
+		enableWebhook, webhookConfig, metricsLabels, ingressURLFormat, ingressClassName, kubeConfig)
 }
 
 func newSparkApplicationController(
@@ -85,7 +134,14 @@ func newSparkApplicationController(
 	kubeClient clientset.Interface,
 	extensionsClient apiextensionsclient.Interface,
 	eventRecorder record.EventRecorder,
-	submissionRunnerWorkers int) *SparkApplicationController {
+	submissionRunnerWorkers int,
+	resyncInterval time.Duration,
+	enableWebhook bool,
+	webhookConfig webhook.Config,
+	metricsLabels []string,
+	ingressURLFormat string,
+	ingressClassName string,
+	kubeConfig *rest.Config) *SparkApplicationController {
 	appStateReportingChan := make(chan appStateUpdate, submissionRunnerWorkers)
 	driverStateReportingChan := make(chan driverStateUpdate)
 	executorStateReportingChan := make(chan executorStateUpdate)
@@ -93,6 +149,22 @@ func newSparkApplicationController(
 	runner := newSparkSubmitRunner(submissionRunnerWorkers, appStateReportingChan)
 	sparkPodMonitor := newSparkPodMonitor(kubeClient, driverStateReportingChan, executorStateReportingChan)
 
+	rateLimiter := workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 1000*time.Second),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(queueTokenRefillRate), queueTokenBucketSize)},
+	)
+	queue := workqueue.NewNamedRateLimitingQueue(rateLimiter, "spark-application-controller")
+	kubecontrollermetrics.RegisterMetricAndTrackRateLimiterUsage("spark_application_controller", queue)
+	metrics.InitWorkQueueMetrics()
+
+	var webhookServer *webhook.WebhookServer
+	if enableWebhook {
+		webhookServer = webhook.New(webhookConfig, kubeClient, crdClient)
+	}
+
+	appMetrics := metrics.NewSparkAppMetrics(metricsLabels)
+	appMetrics.Register()
+
 	return &SparkApplicationController{
 		crdClient:                  crdClient,
 		kubeClient:                 kubeClient,
@@ -103,6 +175,16 @@ func newSparkApplicationController(
 		appStateReportingChan:      appStateReportingChan,
 		driverStateReportingChan:   driverStateReportingChan,
 		executorStateReportingChan: executorStateReportingChan,
+		queue:                      queue,
+		syncWorkers:                submissionRunnerWorkers,
+		resyncInterval:             resyncInterval,
+		statusUpdater:              newCRDStatusUpdater(crdClient),
+		enableWebhook:              enableWebhook,
+		webhookServer:              webhookServer,
+		metrics:                    appMetrics,
+		ingressURLFormat:           ingressURLFormat,
+		ingressClassName:           ingressClassName,
+		kubeConfig:                 kubeConfig,
 		runningApps:                make(map[string]*v1alpha1.SparkApplication),
 	}
 }
@@ -123,6 +205,17 @@ func (s *SparkApplicationController) Start(stopCh <-chan struct{}) error {
 		return fmt.Errorf("failed to register watch for SparkApplication resource: %v", err)
 	}
 
+	if !cache.WaitForCacheSync(stopCh, s.cacheSynced) {
+		return fmt.Errorf("timed out waiting for SparkApplication cache to sync")
+	}
+
+	if s.enableWebhook {
+		glog.Info("Starting the mutating admission webhook")
+		if err := s.webhookServer.Start(); err != nil {
+			return fmt.Errorf("failed to start the mutating admission webhook: %v", err)
+		}
+	}
+
 	go s.runner.run(stopCh)
 	go s.sparkPodMonitor.run(stopCh)
 
@@ -130,54 +223,215 @@ func (s *SparkApplicationController) Start(stopCh <-chan struct{}) error {
 	go s.processDriverStateUpdates()
 	go s.processExecutorStateUpdates()
 
+	for i := 0; i < s.syncWorkers; i++ {
+		go wait.Until(s.runWorker, time.Second, stopCh)
+	}
+
 	return nil
 }
 
+// Stop stops the SparkApplicationController.
+func (s *SparkApplicationController) Stop() {
+	glog.Info("Stopping the SparkApplication controller")
+	if s.enableWebhook {
+		if err := s.webhookServer.Stop(); err != nil {
+			glog.Errorf("failed to stop the mutating admission webhook cleanly: %v", err)
+		}
+	}
+	s.queue.ShutDown()
+}
+
 func (s *SparkApplicationController) startSparkApplicationInformer(stopCh <-chan struct{}) error {
 	informerFactory := crdinformers.NewSharedInformerFactory(
 		s.crdClient,
-		// resyncPeriod. Every resyncPeriod, all resources in the cache will re-trigger events.
-		// Set to 0 to disable the resync.
-		0*time.Second)
-	informer := informerFactory.Sparkoperator().V1alpha1().SparkApplications().Informer()
-	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		// resyncPeriod. Every resyncPeriod, all resources in the cache are re-delivered as Update
+		// events, which lets reconcile catch up on spec changes it might otherwise have missed.
+		s.resyncInterval)
+	informer := informerFactory.Sparkoperator().V1alpha1().SparkApplications()
+	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    s.onAdd,
+		UpdateFunc: s.onUpdate,
 		DeleteFunc: s.onDelete,
 	})
-	go informer.Run(stopCh)
 
-	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
-		return fmt.Errorf("timed out waiting for cache to sync")
-	}
+	s.lister = informer.Lister()
+	s.cacheSynced = informer.Informer().HasSynced
+	go informer.Informer().Run(stopCh)
 
 	return nil
 }
 
 // Callback function called when a new SparkApplication object gets created.
 func (s *SparkApplicationController) onAdd(obj interface{}) {
-	app := obj.(*v1alpha1.SparkApplication)
-	s.recorder.Eventf(
-		app,
-		apiv1.EventTypeNormal,
-		"SparkApplicationSubmission",
-		"Submitting SparkApplication: %s",
-		app.Name)
-	s.submitApp(app, false)
+	s.enqueue(obj)
+}
+
+// onUpdate is the callback invoked when a SparkApplication is edited (e.g. the driver/executor
+// image, args, instance count, or restart policy changed) or on a periodic resync.
+func (s *SparkApplicationController) onUpdate(oldObj, newObj interface{}) {
+	s.enqueue(newObj)
 }
 
 func (s *SparkApplicationController) onDelete(obj interface{}) {
-	app := obj.(*v1alpha1.SparkApplication)
+	s.enqueue(obj)
+}
+
+// enqueue adds the namespace/name key of the given SparkApplication to the work queue.
+func (s *SparkApplicationController) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to get key for object %v: %v", obj, err))
+		return
+	}
+	s.queue.Add(key)
+}
+
+// runWorker runs a worker goroutine that dequeues items from the work queue and processes them
+// until the queue is shut down.
+func (s *SparkApplicationController) runWorker() {
+	defer utilruntime.HandleCrash()
+	for s.processNextItem() {
+	}
+}
+
+func (s *SparkApplicationController) processNextItem() bool {
+	key, quit := s.queue.Get()
+	if quit {
+		return false
+	}
+	defer s.queue.Done(key)
 
-	s.recorder.Eventf(
-		app,
-		apiv1.EventTypeNormal,
-		"SparkApplicationDeletion",
-		"Deleting SparkApplication: %s",
-		app.Name)
+	err := s.syncSparkApplication(key.(string))
+	if err == nil {
+		s.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("failed to sync SparkApplication %q: %v", key, err))
+	s.queue.AddRateLimited(key)
+	return true
+}
 
+// syncSparkApplication is the syncHandler invoked for each key popped off the work queue. It looks
+// up the SparkApplication by the namespace/name key and drives it towards the desired state.
+func (s *SparkApplicationController) syncSparkApplication(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("invalid resource key %q: %v", key, err)
+	}
+
+	app, err := s.lister.SparkApplications(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		glog.V(2).Infof("SparkApplication %s has been deleted", key)
+		s.mutex.Lock()
+		for appID, runningApp := range s.runningApps {
+			if runningApp.Namespace == namespace && runningApp.Name == name {
+				delete(s.runningApps, appID)
+			}
+		}
+		s.mutex.Unlock()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.reconcile(app)
+}
+
+// isSubmissionInFlight reports whether app's namespace/name is already tracked in runningApps,
+// meaning it was submitted by a prior reconcile and is awaiting a state update from the
+// submission runner or driver pod monitor. runningApps is keyed by AppID rather than
+// namespace/name, so this walks the map the same way syncSparkApplication's delete-cleanup does.
+func (s *SparkApplicationController) isSubmissionInFlight(app *v1alpha1.SparkApplication) bool {
 	s.mutex.Lock()
-	delete(s.runningApps, app.Status.AppID)
-	s.mutex.Unlock()
+	defer s.mutex.Unlock()
+	for _, runningApp := range s.runningApps {
+		if runningApp.Namespace == app.Namespace && runningApp.Name == app.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcile compares the SparkApplication's Spec against its observed Status and the live driver
+// pod and decides whether to submit it for the first time, leave it alone, or tear it down and
+// resubmit it because its Spec has changed since the last submission.
+func (s *SparkApplicationController) reconcile(app *v1alpha1.SparkApplication) error {
+	switch app.Status.AppState.State {
+	case v1alpha1.NewState:
+		if s.isSubmissionInFlight(app) {
+			// submitApp's own status patch (setting AppState.State to NewState, which is "") gets
+			// echoed straight back through onUpdate/enqueue before the submission runner has had a
+			// chance to report SubmittedState. Without this guard reconcile would re-enter this
+			// case and call submitApp again for the same app, minting a fresh AppID and re-running
+			// spark-submit every time the informer redelivers the patch as an Update.
+			return nil
+		}
+		s.recorder.Eventf(
+			app,
+			apiv1.EventTypeNormal,
+			"SparkApplicationSubmission",
+			"Submitting SparkApplication: %s",
+			app.Name)
+		s.submitApp(app, false)
+		return nil
+	}
+
+	if isAppTerminated(app.Status.AppState.State) {
+		// Termination is handled by processSingleDriverStateUpdate/handleRestart.
+		return nil
+	}
+
+	if app.Status.DriverInfo.PodName == "" {
+		return nil
+	}
+
+	driverPod, err := s.kubeClient.CoreV1().Pods(app.Namespace).Get(app.Status.DriverInfo.PodName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	desiredHash, err := specHash(app.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to hash Spec of SparkApplication %s: %v", app.Name, err)
+	}
+
+	if driverPod.Annotations[sparkAppSpecHashAnnotation] != desiredHash {
+		glog.Infof("Detected a Spec change for SparkApplication %s, resubmitting it", app.Name)
+		s.recorder.Eventf(
+			app,
+			apiv1.EventTypeNormal,
+			"SparkApplicationSpecUpdated",
+			"Resubmitting SparkApplication %s following a Spec update",
+			app.Name)
+		// Tear down the previous run: stop tracking its AppID so late reports about the old driver
+		// are ignored, then delete the old driver pod.
+		s.mutex.Lock()
+		delete(s.runningApps, app.Status.AppID)
+		s.mutex.Unlock()
+		if err := s.kubeClient.CoreV1().Pods(app.Namespace).Delete(driverPod.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete driver pod %s of SparkApplication %s: %v", driverPod.Name, app.Name, err)
+		}
+		s.submitApp(app, true)
+	}
+
+	return nil
+}
+
+// specHash returns a stable hash of a SparkApplication Spec, used to tag the driver pod so the
+// controller can detect Spec drift after a restart without depending on its in-memory cache.
+func specHash(spec v1alpha1.SparkApplicationSpec) (string, error) {
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	hasher := util.NewHash32()
+	hasher.Write(encoded)
+	return strconv.FormatUint(uint64(hasher.Sum32()), 10), nil
 }
 
 func (s *SparkApplicationController) submitApp(app *v1alpha1.SparkApplication, resubmission bool) {
@@ -192,6 +446,14 @@ func (s *SparkApplicationController) submitApp(app *v1alpha1.SparkApplication, r
 		toUpdate.Status.AppID = buildAppID(toUpdate)
 		toUpdate.Status.AppState.State = v1alpha1.NewState
 		createSparkUIService(toUpdate, s.kubeClient)
+		if s.ingressURLFormat != "" {
+			ingressURL, err := createSparkUIIngress(toUpdate, s.kubeClient, s.ingressURLFormat, s.ingressClassName)
+			if err != nil {
+				glog.Errorf("failed to create UI Ingress for SparkApplication %s: %v", toUpdate.Name, err)
+			} else {
+				toUpdate.Status.DriverInfo.WebUIIngressAddress = ingressURL
+			}
+		}
 	})
 
 	if updatedApp == nil {
@@ -200,6 +462,21 @@ func (s *SparkApplicationController) submitApp(app *v1alpha1.SparkApplication, r
 
 	s.runningApps[updatedApp.Status.AppID] = updatedApp
 
+	// Hash the Spec before the batch scheduler adds its annotations to it, so the hash matches what
+	// reconcile computes from the stored SparkApplication.
+	hash, hashErr := specHash(updatedApp.Spec)
+
+	if updatedApp.Spec.BatchScheduler != nil && *updatedApp.Spec.BatchScheduler != "" {
+		scheduler, err := batchscheduler.ForName(*updatedApp.Spec.BatchScheduler, s.kubeConfig)
+		if err != nil {
+			glog.Errorf("failed to get batch scheduler %q for SparkApplication %s: %v",
+				*updatedApp.Spec.BatchScheduler, updatedApp.Name, err)
+		} else if err := scheduler.DoBatchSchedulingOnSubmission(updatedApp); err != nil {
+			glog.Errorf("failed to run batch scheduler %q for SparkApplication %s: %v",
+				*updatedApp.Spec.BatchScheduler, updatedApp.Name, err)
+		}
+	}
+
 	submissionCmdArgs, err := buildSubmissionCommandArgs(updatedApp)
 	if err != nil {
 		glog.Errorf(
@@ -207,7 +484,14 @@ func (s *SparkApplicationController) submitApp(app *v1alpha1.SparkApplication, r
 			updatedApp.Name,
 			err)
 	}
+	if hashErr == nil {
+		submissionCmdArgs = append(submissionCmdArgs,
+			"--conf", fmt.Sprintf("spark.kubernetes.driver.annotation.%s=%s", sparkAppSpecHashAnnotation, hash))
+	} else {
+		glog.Errorf("failed to compute spec hash for SparkApplication %s: %v", updatedApp.Name, hashErr)
+	}
 	if !updatedApp.Spec.SubmissionByUser {
+		s.metrics.RecordSubmitAttempt(updatedApp)
 		s.runner.submit(newSubmission(submissionCmdArgs, updatedApp))
 	}
 }
@@ -223,11 +507,28 @@ func (s *SparkApplicationController) processDriverStateUpdates() {
 				"SparkApplication %s terminated with state: %v",
 				updatedApp.Name,
 				updatedApp.Status.AppState)
+			s.cleanupBatchScheduling(updatedApp)
 			s.handleRestart(updatedApp)
 		}
 	}
 }
 
+// cleanupBatchScheduling releases any gang-scheduling resources the selected batch scheduler
+// created for app in DoBatchSchedulingOnSubmission.
+func (s *SparkApplicationController) cleanupBatchScheduling(app *v1alpha1.SparkApplication) {
+	if app.Spec.BatchScheduler == nil || *app.Spec.BatchScheduler == "" {
+		return
+	}
+	scheduler, err := batchscheduler.ForName(*app.Spec.BatchScheduler, s.kubeConfig)
+	if err != nil {
+		glog.Errorf("failed to get batch scheduler %q for SparkApplication %s: %v", *app.Spec.BatchScheduler, app.Name, err)
+		return
+	}
+	if err := scheduler.CleanupOnCompletion(app); err != nil {
+		glog.Errorf("failed to clean up batch scheduling for SparkApplication %s: %v", app.Name, err)
+	}
+}
+
 func (s *SparkApplicationController) processSingleDriverStateUpdate(update driverStateUpdate) *v1alpha1.SparkApplication {
 	glog.V(2).Infof(
 		"Received driver state update for %s with phase %s",
@@ -238,9 +539,10 @@ func (s *SparkApplicationController) processSingleDriverStateUpdate(update drive
 	defer s.mutex.Unlock()
 
 	if app, ok := s.runningApps[update.appID]; ok {
+		oldState := app.Status.AppState.State
 		updated := s.updateSparkApplicationWithRetries(app, app.DeepCopy(), func(toUpdate *v1alpha1.SparkApplication) {
 			toUpdate.Status.DriverInfo.PodName = update.podName
-			if update.nodeName != "" {
+			if s.ingressURLFormat == "" && update.nodeName != "" {
 				nodeIP := s.getNodeExternalIP(update.nodeName)
 				if nodeIP != "" {
 					toUpdate.Status.DriverInfo.WebUIAddress = fmt.Sprintf(
@@ -257,6 +559,10 @@ func (s *SparkApplicationController) processSingleDriverStateUpdate(update drive
 
 		if updated != nil {
 			s.runningApps[updated.Status.AppID] = updated
+			s.metrics.ExportAppStateMetrics(updated, oldState, updated.Status.AppState.State)
+			if isAppTerminated(updated.Status.AppState.State) && !updated.Status.SubmissionTime.IsZero() {
+				s.metrics.ObserveDriverRunDuration(updated, updated.Status.SubmissionTime.Time, time.Now())
+			}
 		}
 		return updated
 	}
@@ -275,6 +581,7 @@ func (s *SparkApplicationController) processSingleAppStateUpdate(update appState
 	defer s.mutex.Unlock()
 
 	if app, ok := s.runningApps[update.appID]; ok {
+		oldState := app.Status.AppState.State
 		updated := s.updateSparkApplicationWithRetries(app, app.DeepCopy(), func(toUpdate *v1alpha1.SparkApplication) {
 			// The application termination state is set based on the driver pod termination state. So if the app state
 			// is already a termination state, skip updating the state here. Otherwise, if the submission runner fails
@@ -293,13 +600,20 @@ func (s *SparkApplicationController) processSingleAppStateUpdate(update appState
 
 		if updated != nil {
 			s.runningApps[updated.Status.AppID] = updated
+			s.metrics.ExportAppStateMetrics(updated, oldState, updated.Status.AppState.State)
 			if updated.Status.AppState.State == v1alpha1.FailedSubmissionState {
+				s.metrics.RecordSubmitFailure(updated)
 				s.recorder.Eventf(
 					updated,
 					apiv1.EventTypeNormal,
 					"SparkApplicationSubmissionFailure",
 					"SparkApplication %s failed submission",
 					updated.Name)
+			} else if update.state == v1alpha1.SubmittedState {
+				s.metrics.RecordSubmitSuccess(updated)
+			}
+			if update.state == v1alpha1.RunningState && !updated.Status.SubmissionTime.IsZero() {
+				s.metrics.ObserveSubmissionLatency(updated, updated.Status.SubmissionTime.Time, time.Now())
 			}
 		}
 	}
@@ -322,6 +636,7 @@ func (s *SparkApplicationController) processSingleExecutorStateUpdate(update exe
 	defer s.mutex.Unlock()
 
 	if app, ok := s.runningApps[update.appID]; ok {
+		oldState := app.Status.ExecutorState[update.podName]
 		updated := s.updateSparkApplicationWithRetries(app, app.DeepCopy(), func(toUpdate *v1alpha1.SparkApplication) {
 			if toUpdate.Status.ExecutorState == nil {
 				toUpdate.Status.ExecutorState = make(map[string]v1alpha1.ExecutorState)
@@ -333,37 +648,116 @@ func (s *SparkApplicationController) processSingleExecutorStateUpdate(update exe
 
 		if updated != nil {
 			s.runningApps[updated.Status.AppID] = updated
+			s.metrics.ExportExecutorStateMetrics(updated, oldState, updated.Status.ExecutorState[update.podName])
 		}
 	}
 }
 
+// updateBackoff is the exponential backoff used for retrying status patches that hit a transient
+// API server error. Every caller holds s.mutex, so status writes from the controller are
+// serialized, and status patches are scoped to the /status subresource, so writers never race
+// against concurrent edits to .spec.
+var updateBackoff = wait.Backoff{
+	Duration: 10 * time.Millisecond,
+	Factor:   2,
+	Steps:    4,
+}
+
 func (s *SparkApplicationController) updateSparkApplicationWithRetries(
 	original *v1alpha1.SparkApplication,
 	toUpdate *v1alpha1.SparkApplication,
 	updateFunc func(*v1alpha1.SparkApplication)) *v1alpha1.SparkApplication {
-	for i := 0; i < maximumUpdateRetries; i++ {
-		updateFunc(toUpdate)
-		if reflect.DeepEqual(original.Status, toUpdate.Status) {
-			return nil
-		}
-
-		client := s.crdClient.SparkoperatorV1alpha1().SparkApplications(toUpdate.Namespace)
-		updated, err := client.Update(toUpdate)
-		if err == nil {
-			return updated
-		}
+	updateFunc(toUpdate)
+	if reflect.DeepEqual(original.Status, toUpdate.Status) {
+		return nil
+	}
 
-		// Failed update to the API server.
-		// Get the latest version from the API server first and re-apply the update.
-		name := toUpdate.Name
-		toUpdate, err = client.Get(name, metav1.GetOptions{})
+	var result *v1alpha1.SparkApplication
+	err := retry.OnError(updateBackoff, func(error) bool { return true }, func() error {
+		updated, err := s.statusUpdater.updateStatus(original, toUpdate)
 		if err != nil {
-			glog.Errorf("failed to get SparkApplication %s: %v", name, err)
-			return nil
+			return err
 		}
+		result = updated
+		return nil
+	})
+
+	if err != nil {
+		glog.Errorf("failed to patch the status of SparkApplication %s: %v", toUpdate.Name, err)
+		return nil
 	}
 
-	return nil
+	return result
+}
+
+// ApplicationStatusUpdater updates the Status of a SparkApplication against the API server. It is
+// defined as an interface so unit tests can inject a fake in place of a real API server.
+type ApplicationStatusUpdater interface {
+	// updateStatus patches toUpdate.Status onto the SparkApplication and returns the updated object.
+	updateStatus(original *v1alpha1.SparkApplication, toUpdate *v1alpha1.SparkApplication) (*v1alpha1.SparkApplication, error)
+}
+
+// crdStatusUpdater is the default ApplicationStatusUpdater backed by the CRD API server. It patches
+// only the /status subresource instead of calling Update on the whole object, so it never races
+// with, or clobbers, concurrent edits a user makes to .spec.
+type crdStatusUpdater struct {
+	crdClient crdclientset.Interface
+}
+
+func newCRDStatusUpdater(crdClient crdclientset.Interface) *crdStatusUpdater {
+	return &crdStatusUpdater{crdClient: crdClient}
+}
+
+func (u *crdStatusUpdater) updateStatus(
+	original *v1alpha1.SparkApplication,
+	toUpdate *v1alpha1.SparkApplication) (*v1alpha1.SparkApplication, error) {
+	patchBytes, err := statusMergePatch(original.Status, toUpdate.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the status patch for SparkApplication %s: %v", toUpdate.Name, err)
+	}
+
+	return u.crdClient.SparkoperatorV1alpha1().SparkApplications(toUpdate.Namespace).
+		Patch(toUpdate.Name, types.MergePatchType, patchBytes, "status")
+}
+
+// statusMergePatch builds the JSON Merge Patch (RFC 7386) body for toUpdate's Status. A merge
+// patch only ever replaces keys present in the document, and merges nested objects recursively
+// rather than replacing them outright. SparkApplicationStatus's own `omitempty` tags exist for
+// its regular JSON encoding, but applied here they'd mean submitApp clearing Status back to its
+// zero value on a resubmission produces a patch that leaves the server's stale values in place.
+// So every scalar and struct-typed field below is emitted unconditionally, and ExecutorState --
+// a map, which a merge can only grow or delete outright, never replace -- is explicitly nulled
+// out when it goes from populated to empty.
+func statusMergePatch(original, toUpdate v1alpha1.SparkApplicationStatus) ([]byte, error) {
+	status := map[string]interface{}{
+		"appId": toUpdate.AppID,
+		"applicationState": struct {
+			State        v1alpha1.ApplicationStateType `json:"state"`
+			ErrorMessage string                        `json:"errorMessage"`
+		}{toUpdate.AppState.State, toUpdate.AppState.ErrorMessage},
+		"driverInfo": struct {
+			PodName             string `json:"podName"`
+			WebUIAddress        string `json:"webUIAddress"`
+			WebUIPort           int32  `json:"webUIPort"`
+			WebUIIngressAddress string `json:"webUIIngressAddress"`
+		}{
+			toUpdate.DriverInfo.PodName,
+			toUpdate.DriverInfo.WebUIAddress,
+			toUpdate.DriverInfo.WebUIPort,
+			toUpdate.DriverInfo.WebUIIngressAddress,
+		},
+		"submissionTime": toUpdate.SubmissionTime,
+		"completionTime": toUpdate.CompletionTime,
+	}
+
+	switch {
+	case len(toUpdate.ExecutorState) > 0:
+		status["executorState"] = toUpdate.ExecutorState
+	case len(original.ExecutorState) > 0:
+		status["executorState"] = nil
+	}
+
+	return json.Marshal(map[string]interface{}{"status": status})
 }
 
 func (s *SparkApplicationController) getNodeExternalIP(nodeName string) string {
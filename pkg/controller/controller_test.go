@@ -0,0 +1,128 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+)
+
+// fakeStatusUpdater is an ApplicationStatusUpdater that records every status it was asked to
+// write instead of talking to an API server, so tests can assert on how many times submitApp
+// actually ran.
+type fakeStatusUpdater struct {
+	updates []*v1alpha1.SparkApplication
+}
+
+func (f *fakeStatusUpdater) updateStatus(
+	original *v1alpha1.SparkApplication, toUpdate *v1alpha1.SparkApplication) (*v1alpha1.SparkApplication, error) {
+	updated := toUpdate.DeepCopy()
+	f.updates = append(f.updates, updated)
+	return updated, nil
+}
+
+func newTestController(updater *fakeStatusUpdater) *SparkApplicationController {
+	return &SparkApplicationController{
+		kubeClient:    fake.NewSimpleClientset(),
+		recorder:      record.NewFakeRecorder(100),
+		statusUpdater: updater,
+		runningApps:   make(map[string]*v1alpha1.SparkApplication),
+	}
+}
+
+func newTestApp(name string) *v1alpha1.SparkApplication {
+	return &v1alpha1.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		// SubmissionByUser skips the submission runner, which this test has no use for.
+		Spec: v1alpha1.SparkApplicationSpec{SubmissionByUser: true},
+	}
+}
+
+func TestReconcileSubmitsNewApplicationOnce(t *testing.T) {
+	updater := &fakeStatusUpdater{}
+	controller := newTestController(updater)
+	app := newTestApp("new-app")
+
+	if err := controller.reconcile(app); err != nil {
+		t.Fatalf("reconcile returned an error: %v", err)
+	}
+
+	if len(updater.updates) != 1 {
+		t.Fatalf("expected submitApp to write a status once, got %d", len(updater.updates))
+	}
+	if state := updater.updates[0].Status.AppState.State; state != v1alpha1.NewState {
+		t.Errorf("expected the submitted app to be left in NewState, got %q", state)
+	}
+	if len(controller.runningApps) != 1 {
+		t.Errorf("expected the submitted app to be tracked in runningApps, got %d entries", len(controller.runningApps))
+	}
+}
+
+func TestReconcileDoesNotResubmitWhileSubmissionIsInFlight(t *testing.T) {
+	updater := &fakeStatusUpdater{}
+	controller := newTestController(updater)
+	app := newTestApp("in-flight-app")
+
+	// The lister's copy of app is never updated between these two calls, mirroring how the
+	// informer can redeliver submitApp's own status patch as an Update event -- and therefore a
+	// second reconcile of the same "", NewState app -- before the submission runner has reported
+	// SubmittedState.
+	if err := controller.reconcile(app); err != nil {
+		t.Fatalf("first reconcile returned an error: %v", err)
+	}
+	if err := controller.reconcile(app); err != nil {
+		t.Fatalf("second reconcile returned an error: %v", err)
+	}
+
+	if len(updater.updates) != 1 {
+		t.Fatalf("expected reconcile to guard against resubmission, but submitApp ran %d times", len(updater.updates))
+	}
+}
+
+func TestStatusMergePatchClearsExecutorStateOnResubmission(t *testing.T) {
+	original := v1alpha1.SparkApplicationStatus{
+		ExecutorState: map[string]v1alpha1.ExecutorState{"exec-1": v1alpha1.ExecutorRunningState},
+	}
+	// submitApp resets toUpdate.Status to its zero value on a resubmission.
+	toUpdate := v1alpha1.SparkApplicationStatus{}
+
+	patchBytes, err := statusMergePatch(original, toUpdate)
+	if err != nil {
+		t.Fatalf("statusMergePatch returned an error: %v", err)
+	}
+
+	var patch struct {
+		Status struct {
+			ExecutorState json.RawMessage `json:"executorState"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(patchBytes, &patch); err != nil {
+		t.Fatalf("failed to unmarshal the patch: %v", err)
+	}
+
+	// A merge patch can only grow or delete a map key, never replace it wholesale, so the patch
+	// must null out executorState explicitly instead of omitting it.
+	if string(patch.Status.ExecutorState) != "null" {
+		t.Errorf("expected executorState to be explicitly nulled out, got %s", patch.Status.ExecutorState)
+	}
+}
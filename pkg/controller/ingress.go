@@ -0,0 +1,116 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	extensionsv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+)
+
+const (
+	// sparkUIServiceNameSuffix mirrors the suffix createSparkUIService uses to name the UI's
+	// backing NodePort Service, so the Ingress can target it by name.
+	sparkUIServiceNameSuffix = "-ui-svc"
+	// sparkUIIngressNameSuffix is appended to a SparkApplication's AppID to name its UI Ingress.
+	sparkUIIngressNameSuffix = "-ui-ingress"
+	// sparkUIPortName is the name of the Spark UI port on the backing Service.
+	sparkUIPortName = "spark-driver-ui-port"
+	// sparkUIRole labels the UI Ingress the same way the UI Service is labeled.
+	sparkUIRole = "ui"
+)
+
+// ingressPathType is the only PathType createSparkUIIngress uses; the driver UI is always exposed
+// at the Ingress host's root.
+var ingressPathType = extensionsv1.PathTypeImplementationSpecific
+
+// createSparkUIIngress creates a networking.k8s.io/v1 Ingress that fronts app's Spark UI Service
+// and returns the externally reachable URL derived from urlFormat. urlFormat may reference
+// {{$appName}}, {{$appNamespace}}, and {{$appId}}, which are substituted with the corresponding
+// fields of app before the Ingress host is set. ingressClassName is left unset on the Ingress when
+// empty, so the cluster's default IngressClass applies.
+func createSparkUIIngress(
+	app *v1alpha1.SparkApplication,
+	kubeClient clientset.Interface,
+	urlFormat string,
+	ingressClassName string) (string, error) {
+	ingressURL := resolveIngressURLFormat(urlFormat, app)
+	host := ingressURL
+	if idx := strings.Index(host, "/"); idx != -1 {
+		host = host[:idx]
+	}
+
+	ingress := &extensionsv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.Status.AppID + sparkUIIngressNameSuffix,
+			Namespace: app.Namespace,
+			Labels:    map[string]string{sparkRoleLabel: sparkUIRole},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(app, v1alpha1.SchemeGroupVersion.WithKind("SparkApplication")),
+			},
+		},
+		Spec: extensionsv1.IngressSpec{
+			Rules: []extensionsv1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: extensionsv1.IngressRuleValue{
+						HTTP: &extensionsv1.HTTPIngressRuleValue{
+							Paths: []extensionsv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &ingressPathType,
+									Backend: extensionsv1.IngressBackend{
+										Service: &extensionsv1.IngressServiceBackend{
+											Name: app.Status.AppID + sparkUIServiceNameSuffix,
+											Port: extensionsv1.ServiceBackendPort{
+												Name: sparkUIPortName,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if ingressClassName != "" {
+		ingress.Spec.IngressClassName = &ingressClassName
+	}
+
+	_, err := kubeClient.NetworkingV1().Ingresses(app.Namespace).Create(ingress)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Ingress %s: %v", ingress.Name, err)
+	}
+
+	return fmt.Sprintf("http://%s", ingressURL), nil
+}
+
+func resolveIngressURLFormat(urlFormat string, app *v1alpha1.SparkApplication) string {
+	replacer := strings.NewReplacer(
+		"{{$appName}}", app.Name,
+		"{{$appNamespace}}", app.Namespace,
+		"{{$appId}}", app.Status.AppID,
+	)
+	return replacer.Replace(urlFormat)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics exposes Prometheus metrics for the lifecycle of SparkApplications and for the
+// internals (workqueue depth, adds, latency) of the controllers that manage them.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+)
+
+const namespaceLabel = "namespace"
+
+// SparkAppMetrics holds the Prometheus collectors tracking SparkApplication submissions, state
+// transitions, and timings. labelAllowlist bounds which keys of a SparkApplication's
+// Spec.MetricsLabels are attached to its metrics, so a misconfigured application can't explode
+// metric cardinality.
+type SparkAppMetrics struct {
+	labelAllowlist []string
+	labelNames     []string
+
+	submitCount    *prometheus.CounterVec
+	appStateCount  *prometheus.GaugeVec
+	execStateCount *prometheus.GaugeVec
+
+	submissionLatency *prometheus.HistogramVec
+	driverRunDuration *prometheus.HistogramVec
+}
+
+// NewSparkAppMetrics creates a new SparkAppMetrics. labelAllowlist is the set of
+// Spec.MetricsLabels keys that are allowed to become Prometheus label dimensions; "namespace" is
+// always included.
+func NewSparkAppMetrics(labelAllowlist []string) *SparkAppMetrics {
+	labelNames := append([]string{namespaceLabel}, labelAllowlist...)
+
+	return &SparkAppMetrics{
+		labelAllowlist: labelAllowlist,
+		labelNames:     labelNames,
+		submitCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "spark_app_submit_count",
+			Help: "Count of SparkApplication submission attempts by outcome (attempted, succeeded, failed)",
+		}, append([]string{"outcome"}, labelNames...)),
+		appStateCount: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "spark_app_count",
+			Help: "Number of SparkApplications by state",
+		}, append([]string{"state"}, labelNames...)),
+		execStateCount: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "spark_app_executor_count",
+			Help: "Number of Spark executors by state",
+		}, append([]string{"state"}, labelNames...)),
+		submissionLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "spark_app_submission_to_running_latency_seconds",
+			Help:    "Latency between SparkApplication submission and the driver entering RUNNING",
+			Buckets: prometheus.DefBuckets,
+		}, labelNames),
+		driverRunDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "spark_app_driver_run_duration_seconds",
+			Help:    "Duration from a SparkApplication's submission to its termination",
+			Buckets: prometheus.DefBuckets,
+		}, labelNames),
+	}
+}
+
+// Register registers all of m's collectors with the default Prometheus registry.
+func (m *SparkAppMetrics) Register() {
+	prometheus.MustRegister(m.submitCount, m.appStateCount, m.execStateCount, m.submissionLatency, m.driverRunDuration)
+}
+
+func (m *SparkAppMetrics) labelsFor(app *v1alpha1.SparkApplication) prometheus.Labels {
+	labels := prometheus.Labels{namespaceLabel: app.Namespace}
+	for _, key := range m.labelAllowlist {
+		labels[key] = app.Spec.MetricsLabels[key]
+	}
+	return labels
+}
+
+// RecordSubmitAttempt increments the submission-attempted counter for app.
+func (m *SparkAppMetrics) RecordSubmitAttempt(app *v1alpha1.SparkApplication) {
+	m.incSubmitCount("attempted", app)
+}
+
+// RecordSubmitSuccess increments the submission-succeeded counter for app.
+func (m *SparkAppMetrics) RecordSubmitSuccess(app *v1alpha1.SparkApplication) {
+	m.incSubmitCount("succeeded", app)
+}
+
+// RecordSubmitFailure increments the submission-failed counter for app.
+func (m *SparkAppMetrics) RecordSubmitFailure(app *v1alpha1.SparkApplication) {
+	m.incSubmitCount("failed", app)
+}
+
+func (m *SparkAppMetrics) incSubmitCount(outcome string, app *v1alpha1.SparkApplication) {
+	labels := m.labelsFor(app)
+	labels["outcome"] = outcome
+	m.submitCount.With(labels).Inc()
+}
+
+// ExportAppStateMetrics moves app's gauge count from oldState to newState.
+func (m *SparkAppMetrics) ExportAppStateMetrics(
+	app *v1alpha1.SparkApplication, oldState, newState v1alpha1.ApplicationStateType) {
+	if oldState == newState {
+		return
+	}
+	labels := m.labelsFor(app)
+	if oldState != "" {
+		oldLabels := cloneWith(labels, "state", string(oldState))
+		m.appStateCount.With(oldLabels).Dec()
+	}
+	newLabels := cloneWith(labels, "state", string(newState))
+	m.appStateCount.With(newLabels).Inc()
+}
+
+// ExportExecutorStateMetrics moves an executor's gauge count from oldState to newState.
+func (m *SparkAppMetrics) ExportExecutorStateMetrics(
+	app *v1alpha1.SparkApplication, oldState, newState v1alpha1.ExecutorState) {
+	if oldState == newState {
+		return
+	}
+	labels := m.labelsFor(app)
+	if oldState != "" {
+		oldLabels := cloneWith(labels, "state", string(oldState))
+		m.execStateCount.With(oldLabels).Dec()
+	}
+	newLabels := cloneWith(labels, "state", string(newState))
+	m.execStateCount.With(newLabels).Inc()
+}
+
+// ObserveSubmissionLatency records the time between app's submission and its driver entering
+// RUNNING.
+func (m *SparkAppMetrics) ObserveSubmissionLatency(app *v1alpha1.SparkApplication, submittedAt, runningAt time.Time) {
+	m.submissionLatency.With(m.labelsFor(app)).Observe(runningAt.Sub(submittedAt).Seconds())
+}
+
+// ObserveDriverRunDuration records the time from startedAt to terminatedAt for app. The controller
+// passes the application's SubmissionTime as startedAt; the time the driver entered RUNNING is not
+// recorded, so the observed duration also covers the time before the driver started running.
+func (m *SparkAppMetrics) ObserveDriverRunDuration(app *v1alpha1.SparkApplication, startedAt, terminatedAt time.Time) {
+	m.driverRunDuration.With(m.labelsFor(app)).Observe(terminatedAt.Sub(startedAt).Seconds())
+}
+
+func cloneWith(labels prometheus.Labels, key, value string) prometheus.Labels {
+	out := make(prometheus.Labels, len(labels)+1)
+	for k, v := range labels {
+		out[k] = v
+	}
+	out[key] = value
+	return out
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// workqueueMetricsProvider implements workqueue.MetricsProvider by backing every metric
+// client-go's workqueue package asks for with a Prometheus collector, labeled by queue name. It's
+// installed once via InitWorkQueueMetrics so that every RateLimitingQueue created afterwards
+// (SparkApplicationController's and ScheduledSparkApplication's) reports depth, adds, latency, and
+// work duration automatically.
+type workqueueMetricsProvider struct{}
+
+var registerWorkqueueMetricsOnce sync.Once
+
+// InitWorkQueueMetrics installs the Prometheus-backed workqueue.MetricsProvider. It is safe to
+// call more than once; only the first call takes effect.
+func InitWorkQueueMetrics() {
+	registerWorkqueueMetricsOnce.Do(func() {
+		workqueue.SetProvider(workqueueMetricsProvider{})
+	})
+}
+
+func (workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return newGaugeVecMetric("spark_operator_workqueue_depth", "Current depth of the workqueue", name)
+}
+
+func (workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return newCounterVecMetric("spark_operator_workqueue_adds_total", "Total number of items added to the workqueue", name)
+}
+
+func (workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return newHistogramVecMetric(
+		"spark_operator_workqueue_queue_duration_seconds",
+		"How long an item stays in the workqueue before being processed",
+		name)
+}
+
+func (workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return newHistogramVecMetric(
+		"spark_operator_workqueue_work_duration_seconds",
+		"How long processing an item from the workqueue takes",
+		name)
+}
+
+func (workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return newGaugeVecMetric(
+		"spark_operator_workqueue_unfinished_work_seconds",
+		"How long unfinished items in the workqueue have been in progress",
+		name)
+}
+
+func (workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return newGaugeVecMetric(
+		"spark_operator_workqueue_longest_running_processor_seconds",
+		"How long the longest running processor for the workqueue has been running",
+		name)
+}
+
+func (workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return newCounterVecMetric("spark_operator_workqueue_retries_total", "Total number of retries handled by the workqueue", name)
+}
+
+var (
+	gaugeVecs     = map[string]*prometheus.GaugeVec{}
+	counterVecs   = map[string]*prometheus.CounterVec{}
+	histogramVecs = map[string]*prometheus.HistogramVec{}
+	vecsMutex     sync.Mutex
+)
+
+// newGaugeVecMetric returns the Gauge for queueName out of the GaugeVec registered under
+// metricName, creating and registering the GaugeVec the first time it's asked for. It returns the
+// curried-down prometheus.Gauge itself (via With), not the Vec, since the Vec doesn't implement
+// Inc/Dec/Set and so can't satisfy workqueue.GaugeMetric/SettableGaugeMetric.
+func newGaugeVecMetric(metricName, help, queueName string) prometheus.Gauge {
+	vecsMutex.Lock()
+	defer vecsMutex.Unlock()
+	vec, ok := gaugeVecs[metricName]
+	if !ok {
+		vec = prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: metricName, Help: help}, []string{"name"})
+		prometheus.MustRegister(vec)
+		gaugeVecs[metricName] = vec
+	}
+	return vec.With(prometheus.Labels{"name": queueName})
+}
+
+// newCounterVecMetric returns the Counter for queueName out of the CounterVec registered under
+// metricName, creating and registering the CounterVec the first time it's asked for.
+func newCounterVecMetric(metricName, help, queueName string) prometheus.Counter {
+	vecsMutex.Lock()
+	defer vecsMutex.Unlock()
+	vec, ok := counterVecs[metricName]
+	if !ok {
+		vec = prometheus.NewCounterVec(prometheus.CounterOpts{Name: metricName, Help: help}, []string{"name"})
+		prometheus.MustRegister(vec)
+		counterVecs[metricName] = vec
+	}
+	return vec.With(prometheus.Labels{"name": queueName})
+}
+
+// newHistogramVecMetric returns the Observer for queueName out of the HistogramVec registered
+// under metricName, creating and registering the HistogramVec the first time it's asked for.
+func newHistogramVecMetric(metricName, help, queueName string) prometheus.Observer {
+	vecsMutex.Lock()
+	defer vecsMutex.Unlock()
+	vec, ok := histogramVecs[metricName]
+	if !ok {
+		vec = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: metricName, Help: help}, []string{"name"})
+		prometheus.MustRegister(vec)
+		histogramVecs[metricName] = vec
+	}
+	return vec.With(prometheus.Labels{"name": queueName})
+}
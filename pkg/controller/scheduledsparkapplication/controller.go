@@ -0,0 +1,373 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scheduledsparkapplication implements a controller for ScheduledSparkApplication objects,
+// creating a SparkApplication on each due run of the cron Schedule.
+package scheduledsparkapplication
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/robfig/cron"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/clock"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+	crdclientset "k8s.io/spark-on-k8s-operator/pkg/client/clientset/versioned"
+	crdinformers "k8s.io/spark-on-k8s-operator/pkg/client/informers/externalversions"
+	crdlisters "k8s.io/spark-on-k8s-operator/pkg/client/listers/sparkoperator.k8s.io/v1alpha1"
+)
+
+const controllerAgentName = "scheduledsparkapplication-controller"
+
+// Controller creates a SparkApplication on every due run of a ScheduledSparkApplication's cron
+// Schedule, and prunes the history of past runs according to its history limits.
+type Controller struct {
+	crdClient      crdclientset.Interface
+	recorder       record.EventRecorder
+	queue          workqueue.RateLimitingInterface
+	lister         crdlisters.ScheduledSparkApplicationLister
+	appLister      crdlisters.SparkApplicationLister
+	cacheSynced    cache.InformerSynced
+	appCacheSynced cache.InformerSynced
+	clock          clock.Clock
+}
+
+// New creates a new Controller for ScheduledSparkApplications.
+func New(
+	crdClient crdclientset.Interface,
+	recorder record.EventRecorder,
+	aClock clock.Clock) *Controller {
+	return &Controller{
+		crdClient: crdClient,
+		recorder:  recorder,
+		queue:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), controllerAgentName),
+		clock:     aClock,
+	}
+}
+
+// Start starts the Controller by registering a watcher for ScheduledSparkApplication objects.
+func (c *Controller) Start(workers int, stopCh <-chan struct{}) error {
+	glog.Info("Starting the ScheduledSparkApplication controller")
+
+	informerFactory := crdinformers.NewSharedInformerFactory(c.crdClient, 0*time.Second)
+	informer := informerFactory.Sparkoperator().V1alpha1().ScheduledSparkApplications()
+	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) { c.enqueue(newObj) },
+		DeleteFunc: c.enqueue,
+	})
+	c.lister = informer.Lister()
+	c.cacheSynced = informer.Informer().HasSynced
+	go informer.Informer().Run(stopCh)
+
+	appInformer := informerFactory.Sparkoperator().V1alpha1().SparkApplications()
+	c.appLister = appInformer.Lister()
+	c.appCacheSynced = appInformer.Informer().HasSynced
+	go appInformer.Informer().Run(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, c.cacheSynced, c.appCacheSynced) {
+		return fmt.Errorf("timed out waiting for ScheduledSparkApplication caches to sync")
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	return nil
+}
+
+// Stop stops the Controller.
+func (c *Controller) Stop() {
+	glog.Info("Stopping the ScheduledSparkApplication controller")
+	c.queue.ShutDown()
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to get key for object %v: %v", obj, err))
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *Controller) runWorker() {
+	defer utilruntime.HandleCrash()
+	for c.processNextItem() {
+	}
+}
+
+func (c *Controller) processNextItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	requeueAfter, err := c.syncScheduledSparkApplication(key.(string))
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to sync ScheduledSparkApplication %q: %v", key, err))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+
+	c.queue.Forget(key)
+	if requeueAfter > 0 {
+		c.queue.AddAfter(key, requeueAfter)
+	}
+	return true
+}
+
+// syncScheduledSparkApplication reconciles a single ScheduledSparkApplication and returns how long
+// the controller should wait before checking it again, which is normally the time until its next
+// scheduled run.
+func (c *Controller) syncScheduledSparkApplication(key string) (time.Duration, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return 0, fmt.Errorf("invalid resource key %q: %v", key, err)
+	}
+
+	app, err := c.lister.ScheduledSparkApplications(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	schedule, err := cron.ParseStandard(app.Spec.Schedule)
+	if err != nil {
+		return 0, c.updateScheduleState(app, v1alpha1.FailedValidationState,
+			fmt.Sprintf("failed to parse schedule %q: %v", app.Spec.Schedule, err))
+	}
+
+	if app.Spec.Suspend != nil && *app.Spec.Suspend {
+		return 0, nil
+	}
+
+	now := c.clock.Now()
+	lastRun := app.Status.LastRun.Time
+	next := schedule.Next(lastRun)
+	if lastRun.IsZero() {
+		next = now
+	}
+
+	if now.Before(next) {
+		return next.Sub(now), nil
+	}
+
+	if err := c.handleConcurrency(app); err != nil {
+		return 0, err
+	}
+
+	newApp, err := c.createSparkApplication(app)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create a SparkApplication for ScheduledSparkApplication %s: %v", name, err)
+	}
+
+	c.recorder.Eventf(
+		app,
+		apiv1.EventTypeNormal,
+		"SparkApplicationScheduled",
+		"Created SparkApplication %s for scheduled run",
+		newApp.Name)
+
+	if err := c.updateStatusAfterRun(app, newApp, now, schedule.Next(now)); err != nil {
+		return 0, err
+	}
+
+	// pruneHistory writes Status again, so it must start from the object updateStatusAfterRun left,
+	// not from app, or it would overwrite LastRun and the rest of that update.
+	updated, err := c.crdClient.SparkoperatorV1alpha1().ScheduledSparkApplications(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		glog.Errorf("failed to get ScheduledSparkApplication %s for pruning its run history: %v", name, err)
+	} else if err := c.pruneHistory(updated); err != nil {
+		glog.Errorf("failed to prune run history for ScheduledSparkApplication %s: %v", name, err)
+	}
+
+	return schedule.Next(now).Sub(now), nil
+}
+
+// handleConcurrency enforces the ScheduledSparkApplication's ConcurrencyPolicy against the
+// SparkApplication created by the previous run, if it is still active.
+func (c *Controller) handleConcurrency(app *v1alpha1.ScheduledSparkApplication) error {
+	if app.Status.LastRunName == "" {
+		return nil
+	}
+
+	lastRun, err := c.appLister.SparkApplications(app.Namespace).Get(app.Status.LastRunName)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if isAppTerminated(lastRun.Status.AppState.State) || lastRun.Status.AppState.State == v1alpha1.FailedSubmissionState {
+		return nil
+	}
+
+	switch app.Spec.ConcurrencyPolicy {
+	case v1alpha1.ConcurrencyForbid:
+		return fmt.Errorf("previous run %s is still active, deferring this run", lastRun.Name)
+	case v1alpha1.ConcurrencyReplace:
+		return c.crdClient.SparkoperatorV1alpha1().SparkApplications(app.Namespace).Delete(lastRun.Name, &metav1.DeleteOptions{})
+	default:
+		// ConcurrencyAllow, or unset: let the new run proceed alongside the old one.
+		return nil
+	}
+}
+
+// createSparkApplication instantiates app.Spec.Template as a new SparkApplication, owned by app so
+// that deleting the ScheduledSparkApplication garbage-collects the SparkApplications it created.
+func (c *Controller) createSparkApplication(app *v1alpha1.ScheduledSparkApplication) (*v1alpha1.SparkApplication, error) {
+	newApp := &v1alpha1.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: app.Name + "-",
+			Namespace:    app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(app, v1alpha1.SchemeGroupVersion.WithKind("ScheduledSparkApplication")),
+			},
+		},
+		Spec: app.Spec.Template,
+	}
+
+	return c.crdClient.SparkoperatorV1alpha1().SparkApplications(app.Namespace).Create(newApp)
+}
+
+func (c *Controller) updateStatusAfterRun(
+	app *v1alpha1.ScheduledSparkApplication,
+	newApp *v1alpha1.SparkApplication,
+	lastRun, nextRun time.Time) error {
+	toUpdate := app.DeepCopy()
+	toUpdate.Status.LastRun = metav1.NewTime(lastRun)
+	toUpdate.Status.NextRun = metav1.NewTime(nextRun)
+	toUpdate.Status.LastRunName = newApp.Name
+	toUpdate.Status.ScheduleState = v1alpha1.ScheduledState
+	toUpdate.Status.Reason = ""
+	return c.updateStatusWithRetries(toUpdate)
+}
+
+func (c *Controller) updateScheduleState(
+	app *v1alpha1.ScheduledSparkApplication, state v1alpha1.ScheduleState, reason string) error {
+	toUpdate := app.DeepCopy()
+	toUpdate.Status.ScheduleState = state
+	toUpdate.Status.Reason = reason
+	return c.updateStatusWithRetries(toUpdate)
+}
+
+var statusUpdateBackoff = wait.Backoff{Duration: 10 * time.Millisecond, Factor: 2, Steps: 4}
+
+func (c *Controller) updateStatusWithRetries(toUpdate *v1alpha1.ScheduledSparkApplication) error {
+	return retry.RetryOnConflict(statusUpdateBackoff, func() error {
+		_, err := c.crdClient.SparkoperatorV1alpha1().ScheduledSparkApplications(toUpdate.Namespace).Update(toUpdate)
+		return err
+	})
+}
+
+// pruneHistory deletes the oldest SparkApplications created for past runs of app beyond its
+// SuccessfulRunHistoryLimit and FailedRunHistoryLimit, then records the names of the runs that
+// survived pruning in app's Status. Garbage collection of the ones that get deleted here is
+// immediate; the owner reference also ensures they're cleaned up if the ScheduledSparkApplication
+// itself is deleted.
+func (c *Controller) pruneHistory(app *v1alpha1.ScheduledSparkApplication) error {
+	apps, err := c.appLister.SparkApplications(app.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	var successful, failed []*v1alpha1.SparkApplication
+	for _, a := range apps {
+		if !isOwnedBy(a, app) {
+			continue
+		}
+		switch a.Status.AppState.State {
+		case v1alpha1.CompletedState:
+			successful = append(successful, a)
+		case v1alpha1.FailedState, v1alpha1.FailedSubmissionState:
+			failed = append(failed, a)
+		}
+	}
+
+	successful, err = c.deleteBeyondLimit(successful, app.Spec.SuccessfulRunHistoryLimit)
+	if err != nil {
+		return err
+	}
+	failed, err = c.deleteBeyondLimit(failed, app.Spec.FailedRunHistoryLimit)
+	if err != nil {
+		return err
+	}
+
+	toUpdate := app.DeepCopy()
+	toUpdate.Status.PastSuccessfulRunNames = runNames(successful)
+	toUpdate.Status.PastFailedRunNames = runNames(failed)
+	return c.updateStatusWithRetries(toUpdate)
+}
+
+// deleteBeyondLimit deletes the oldest of apps beyond limit and returns the ones that remain.
+func (c *Controller) deleteBeyondLimit(apps []*v1alpha1.SparkApplication, limit *int32) ([]*v1alpha1.SparkApplication, error) {
+	if limit == nil || len(apps) <= int(*limit) {
+		return apps, nil
+	}
+
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].CreationTimestamp.Before(&apps[j].CreationTimestamp)
+	})
+
+	for _, a := range apps[:len(apps)-int(*limit)] {
+		if err := c.crdClient.SparkoperatorV1alpha1().SparkApplications(a.Namespace).
+			Delete(a.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return apps[len(apps)-int(*limit):], nil
+}
+
+// runNames returns the names of apps, in the same order, for recording in Status.
+func runNames(apps []*v1alpha1.SparkApplication) []string {
+	names := make([]string, 0, len(apps))
+	for _, a := range apps {
+		names = append(names, a.Name)
+	}
+	return names
+}
+
+func isOwnedBy(app *v1alpha1.SparkApplication, owner *v1alpha1.ScheduledSparkApplication) bool {
+	for _, ref := range app.OwnerReferences {
+		if ref.UID == owner.UID {
+			return true
+		}
+	}
+	return false
+}
+
+func isAppTerminated(state v1alpha1.ApplicationStateType) bool {
+	return state == v1alpha1.CompletedState || state == v1alpha1.FailedState
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2018 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduledsparkapplication
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/record"
+
+	"k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+	crdclientsetfake "k8s.io/spark-on-k8s-operator/pkg/client/clientset/versioned/fake"
+	crdinformers "k8s.io/spark-on-k8s-operator/pkg/client/informers/externalversions"
+)
+
+// newTestController wires up a Controller against a fake clientset and aClock, seeding its
+// listers directly from the indexer so tests don't need to start and wait on the informers.
+func newTestController(aClock clock.Clock, objects ...runtime.Object) *Controller {
+	crdClient := crdclientsetfake.NewSimpleClientset(objects...)
+	informerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0*time.Second)
+	scheduledInformer := informerFactory.Sparkoperator().V1alpha1().ScheduledSparkApplications()
+	appInformer := informerFactory.Sparkoperator().V1alpha1().SparkApplications()
+
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *v1alpha1.ScheduledSparkApplication:
+			scheduledInformer.Informer().GetIndexer().Add(o)
+		case *v1alpha1.SparkApplication:
+			appInformer.Informer().GetIndexer().Add(o)
+		}
+	}
+
+	return &Controller{
+		crdClient: crdClient,
+		recorder:  record.NewFakeRecorder(100),
+		lister:    scheduledInformer.Lister(),
+		appLister: appInformer.Lister(),
+		clock:     aClock,
+	}
+}
+
+func TestSyncScheduledSparkApplicationTriggersDueRun(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	app := &v1alpha1.ScheduledSparkApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "every-minute", Namespace: "default"},
+		Spec: v1alpha1.ScheduledSparkApplicationSpec{
+			Schedule: "* * * * *",
+		},
+	}
+	c := newTestController(clock.NewFakeClock(now), app)
+
+	requeueAfter, err := c.syncScheduledSparkApplication("default/every-minute")
+	if err != nil {
+		t.Fatalf("syncScheduledSparkApplication returned an error: %v", err)
+	}
+	if requeueAfter <= 0 || requeueAfter > time.Minute {
+		t.Errorf("expected the next run to be requeued within a minute, got %v", requeueAfter)
+	}
+
+	created, err := c.crdClient.SparkoperatorV1alpha1().SparkApplications("default").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list created SparkApplications: %v", err)
+	}
+	if len(created.Items) != 1 {
+		t.Fatalf("expected syncScheduledSparkApplication to create one SparkApplication, got %d", len(created.Items))
+	}
+
+	updated, err := c.crdClient.SparkoperatorV1alpha1().ScheduledSparkApplications("default").Get("every-minute", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the updated ScheduledSparkApplication: %v", err)
+	}
+	if !updated.Status.LastRun.Time.Equal(now) {
+		t.Errorf("expected LastRun to be set to the fake clock's now (%v), got %v", now, updated.Status.LastRun.Time)
+	}
+}
+
+func TestPruneHistoryDeletesOldestRunsBeyondLimit(t *testing.T) {
+	var limit int32 = 1
+	app := &v1alpha1.ScheduledSparkApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "default", UID: "owner-uid"},
+		Spec: v1alpha1.ScheduledSparkApplicationSpec{
+			Schedule:                  "* * * * *",
+			SuccessfulRunHistoryLimit: &limit,
+		},
+	}
+	ownerRef := *metav1.NewControllerRef(app, v1alpha1.SchemeGroupVersion.WithKind("ScheduledSparkApplication"))
+
+	older := &v1alpha1.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "older-run", Namespace: "default",
+			CreationTimestamp: metav1.NewTime(time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)),
+			OwnerReferences:   []metav1.OwnerReference{ownerRef},
+		},
+		Status: v1alpha1.SparkApplicationStatus{AppState: v1alpha1.ApplicationState{State: v1alpha1.CompletedState}},
+	}
+	newer := &v1alpha1.SparkApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "newer-run", Namespace: "default",
+			CreationTimestamp: metav1.NewTime(time.Date(2018, 6, 2, 0, 0, 0, 0, time.UTC)),
+			OwnerReferences:   []metav1.OwnerReference{ownerRef},
+		},
+		Status: v1alpha1.SparkApplicationStatus{AppState: v1alpha1.ApplicationState{State: v1alpha1.CompletedState}},
+	}
+
+	c := newTestController(clock.NewFakeClock(time.Now()), app, older, newer)
+
+	if err := c.pruneHistory(app); err != nil {
+		t.Fatalf("pruneHistory returned an error: %v", err)
+	}
+
+	remaining, err := c.crdClient.SparkoperatorV1alpha1().SparkApplications("default").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list remaining SparkApplications: %v", err)
+	}
+	if len(remaining.Items) != 1 || remaining.Items[0].Name != "newer-run" {
+		t.Errorf("expected only the newer run to survive pruning beyond the history limit, got %v", remaining.Items)
+	}
+
+	updated, err := c.crdClient.SparkoperatorV1alpha1().ScheduledSparkApplications("default").Get("owner", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the updated ScheduledSparkApplication: %v", err)
+	}
+	if names := updated.Status.PastSuccessfulRunNames; len(names) != 1 || names[0] != "newer-run" {
+		t.Errorf("expected PastSuccessfulRunNames to record the surviving run, got %v", names)
+	}
+}
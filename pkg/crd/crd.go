@@ -0,0 +1,97 @@
+/*
+Copyright 2017 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crd installs the CustomResourceDefinitions the operator depends on.
+package crd
+
+import (
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+)
+
+const (
+	// FullName is the fully-qualified name of the SparkApplication CustomResourceDefinition.
+	FullName = "sparkapplications." + v1alpha1.GroupName
+	// Plural is the plural name of the SparkApplication custom resource.
+	Plural = "sparkapplications"
+	// Singular is the singular name of the SparkApplication custom resource.
+	Singular = "sparkapplication"
+	// Kind is the kind of the SparkApplication custom resource.
+	Kind = "SparkApplication"
+
+	// ScheduledFullName is the fully-qualified name of the ScheduledSparkApplication
+	// CustomResourceDefinition.
+	ScheduledFullName = "scheduledsparkapplications." + v1alpha1.GroupName
+	// ScheduledPlural is the plural name of the ScheduledSparkApplication custom resource.
+	ScheduledPlural = "scheduledsparkapplications"
+	// ScheduledSingular is the singular name of the ScheduledSparkApplication custom resource.
+	ScheduledSingular = "scheduledsparkapplication"
+	// ScheduledKind is the kind of the ScheduledSparkApplication custom resource.
+	ScheduledKind = "ScheduledSparkApplication"
+)
+
+// CreateCRD creates the SparkApplication and ScheduledSparkApplication CustomResourceDefinitions
+// if they don't already exist.
+func CreateCRD(extensionsClient apiextensionsclient.Interface) error {
+	if err := createCRD(extensionsClient, &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: FullName},
+		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+			Group:   v1alpha1.GroupName,
+			Version: v1alpha1.SchemeGroupVersion.Version,
+			Scope:   apiextensionsv1beta1.NamespaceScoped,
+			Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
+				Plural:   Plural,
+				Singular: Singular,
+				Kind:     Kind,
+			},
+			// The status subresource gives the SparkApplication a distinct /status endpoint, which
+			// crdStatusUpdater relies on to merge-patch Status without touching Spec.
+			Subresources: &apiextensionsv1beta1.CustomResourceSubresources{
+				Status: &apiextensionsv1beta1.CustomResourceSubresourceStatus{},
+			},
+		},
+	}); err != nil {
+		return err
+	}
+
+	return createCRD(extensionsClient, &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: ScheduledFullName},
+		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+			Group:   v1alpha1.GroupName,
+			Version: v1alpha1.SchemeGroupVersion.Version,
+			Scope:   apiextensionsv1beta1.NamespaceScoped,
+			Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
+				Plural:   ScheduledPlural,
+				Singular: ScheduledSingular,
+				Kind:     ScheduledKind,
+			},
+		},
+	})
+}
+
+func createCRD(
+	extensionsClient apiextensionsclient.Interface,
+	crd *apiextensionsv1beta1.CustomResourceDefinition) error {
+	_, err := extensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().Create(crd)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2017 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+)
+
+const (
+	webhookConfigName = "spark-webhook-config"
+	webhookName       = "webhook.sparkoperator.k8s.io"
+	mutatePath        = "/webhook"
+)
+
+// installMutatingWebhookConfiguration creates, or updates an existing, MutatingWebhookConfiguration
+// that routes every pod CREATE admission request to the webhook service. The rule does not filter
+// on labels; the server itself lets pods without sparkRoleLabel through unmodified.
+func installMutatingWebhookConfiguration(
+	kubeClient clientset.Interface,
+	serviceName, namespace string,
+	caCert []byte) error {
+	path := mutatePath
+	failurePolicy := admissionregistrationv1beta1.Ignore
+	webhookConfig := &admissionregistrationv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: webhookConfigName},
+		Webhooks: []admissionregistrationv1beta1.Webhook{
+			{
+				Name: webhookName,
+				ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+					Service: &admissionregistrationv1beta1.ServiceReference{
+						Namespace: namespace,
+						Name:      serviceName,
+						Path:      &path,
+					},
+					CABundle: caCert,
+				},
+				Rules: []admissionregistrationv1beta1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1beta1.OperationType{admissionregistrationv1beta1.Create},
+						Rule: admissionregistrationv1beta1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"pods"},
+						},
+					},
+				},
+				FailurePolicy: &failurePolicy,
+			},
+		},
+	}
+
+	client := kubeClient.AdmissionregistrationV1beta1().MutatingWebhookConfigurations()
+	_, err := client.Create(webhookConfig)
+	if errors.IsAlreadyExists(err) {
+		_, err = client.Update(webhookConfig)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to install the MutatingWebhookConfiguration %s: %v", webhookConfigName, err)
+	}
+	return nil
+}
+
+// uninstallMutatingWebhookConfiguration removes the MutatingWebhookConfiguration on shutdown.
+func uninstallMutatingWebhookConfiguration(kubeClient clientset.Interface) error {
+	err := kubeClient.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().
+		Delete(webhookConfigName, &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
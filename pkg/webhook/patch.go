@@ -0,0 +1,107 @@
+/*
+Copyright 2017 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+
+	"k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+)
+
+// patchOperation is a single operation of a JSON Patch (RFC 6902), the format the admission API
+// expects AdmissionResponse.Patch to be encoded as.
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// buildPatch builds the list of JSON patch operations that add the SparkApplication's
+// webhook-relevant pod customization -- volumes, tolerations, node selector, affinity, and
+// sidecars -- to the incoming driver or executor pod.
+func buildPatch(pod *apiv1.Pod, role string, app *v1alpha1.SparkApplication) []patchOperation {
+	var spec v1alpha1.SparkPodSpec
+	var nodeSelector map[string]string
+	var affinity *apiv1.Affinity
+	switch role {
+	case sparkDriverRole:
+		spec = app.Spec.Driver.SparkPodSpec
+		nodeSelector = app.Spec.Driver.NodeSelector
+		affinity = app.Spec.Driver.Affinity
+	case sparkExecutorRole:
+		spec = app.Spec.Executor.SparkPodSpec
+		nodeSelector = app.Spec.Executor.NodeSelector
+		affinity = app.Spec.Executor.Affinity
+	default:
+		return nil
+	}
+
+	var patchOps []patchOperation
+	if len(spec.Volumes) > 0 {
+		patchOps = append(patchOps, addVolumesPatch(pod, spec.Volumes)...)
+	}
+	if len(spec.Tolerations) > 0 {
+		patchOps = append(patchOps, patchOperation{
+			Op:    "add",
+			Path:  "/spec/tolerations",
+			Value: append(append([]apiv1.Toleration{}, pod.Spec.Tolerations...), spec.Tolerations...),
+		})
+	}
+	if len(nodeSelector) > 0 {
+		patchOps = append(patchOps, patchOperation{Op: "add", Path: "/spec/nodeSelector", Value: nodeSelector})
+	}
+	if affinity != nil {
+		patchOps = append(patchOps, patchOperation{Op: "add", Path: "/spec/affinity", Value: affinity})
+	}
+	if len(spec.Sidecars) > 0 {
+		patchOps = append(patchOps, addSidecarsPatch(spec.Sidecars)...)
+	}
+
+	return patchOps
+}
+
+const (
+	sparkDriverRole   = "driver"
+	sparkExecutorRole = "executor"
+)
+
+// addVolumesPatch patches the Volumes into the pod spec. It adds no VolumeMount to any container,
+// so the volumes are not mounted into the Spark container by this patch.
+func addVolumesPatch(pod *apiv1.Pod, volumes []apiv1.Volume) []patchOperation {
+	var ops []patchOperation
+	volumesPath := "/spec/volumes"
+	if len(pod.Spec.Volumes) == 0 {
+		ops = append(ops, patchOperation{Op: "add", Path: volumesPath, Value: volumes})
+		return ops
+	}
+
+	for _, v := range volumes {
+		ops = append(ops, patchOperation{Op: "add", Path: volumesPath + "/-", Value: v})
+	}
+	return ops
+}
+
+// addSidecarsPatch appends one JSON Patch "add" operation per sidecar container. A single "add"
+// at the /-  append path can only carry one element per RFC 6902; sending the whole slice as one
+// operation's value would append a single malformed entry instead of one container per sidecar.
+func addSidecarsPatch(sidecars []apiv1.Container) []patchOperation {
+	var ops []patchOperation
+	for _, c := range sidecars {
+		ops = append(ops, patchOperation{Op: "add", Path: "/spec/containers/-", Value: c})
+	}
+	return ops
+}
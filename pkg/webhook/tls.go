@@ -0,0 +1,74 @@
+/*
+Copyright 2017 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// selfSignedCertTTL is how long the webhook's bootstrapped self-signed certificate is valid for.
+const selfSignedCertTTL = 365 * 24 * time.Hour
+
+// certPair is a PEM-encoded certificate and private key pair.
+type certPair struct {
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// generateSelfSignedCert generates a self-signed TLS certificate for the webhook server, with a
+// Subject Alternative Name covering the in-cluster DNS name of the given Service.
+func generateSelfSignedCert(serviceName, namespace string) (*certPair, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the webhook private key: %v", err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate a certificate serial number: %v", err)
+	}
+
+	dnsName := fmt.Sprintf("%s.%s.svc", serviceName, namespace)
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName, fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, namespace)},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(selfSignedCertTTL),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+		BasicConstraintsValid: true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the webhook self-signed certificate: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	return &certPair{certPEM: certPEM, keyPEM: keyPEM}, nil
+}
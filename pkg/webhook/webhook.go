@@ -0,0 +1,221 @@
+/*
+Copyright 2017 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook implements a mutating admission webhook that injects Spark-specific
+// configuration -- volumes, tolerations, node selectors, affinity, and sidecars -- into driver and
+// executor pods on creation, based on the SparkApplication that owns them.
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/golang/glog"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"k8s.io/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
+	crdclientset "k8s.io/spark-on-k8s-operator/pkg/client/clientset/versioned"
+)
+
+const (
+	// SparkAppIDLabel is the label the operator stamps on driver and executor pods with the
+	// SparkApplication's app ID, so the webhook can look up the owning SparkApplication.
+	SparkAppIDLabel = "sparkoperator.k8s.io/app-id"
+	sparkRoleLabel  = "spark-role"
+)
+
+var (
+	universalDeserializer = func() runtime.Decoder {
+		scheme := runtime.NewScheme()
+		codecs := serializer.NewCodecFactory(scheme)
+		return codecs.UniversalDeserializer()
+	}()
+)
+
+// Config holds the configuration of the webhook server.
+type Config struct {
+	// Namespace is the namespace the operator, and hence the webhook Service, run in.
+	Namespace string
+	// ServiceName is the name of the Service that fronts the webhook server.
+	ServiceName string
+	// Port is the port the webhook's HTTPS server listens on.
+	Port int
+}
+
+// WebhookServer is a mutating admission webhook server that customizes driver and executor pods
+// based on the SparkApplication.Spec of the SparkApplication that owns them.
+type WebhookServer struct {
+	config     Config
+	kubeClient clientset.Interface
+	crdClient  crdclientset.Interface
+	server     *http.Server
+}
+
+// New creates a new WebhookServer.
+func New(config Config, kubeClient clientset.Interface, crdClient crdclientset.Interface) *WebhookServer {
+	return &WebhookServer{
+		config:     config,
+		kubeClient: kubeClient,
+		crdClient:  crdClient,
+	}
+}
+
+// Start bootstraps a self-signed TLS certificate, installs the MutatingWebhookConfiguration, and
+// starts serving admission requests.
+func (ws *WebhookServer) Start() error {
+	cert, err := generateSelfSignedCert(ws.config.ServiceName, ws.config.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to bootstrap the webhook TLS certificate: %v", err)
+	}
+
+	tlsCert, err := tls.X509KeyPair(cert.certPEM, cert.keyPEM)
+	if err != nil {
+		return fmt.Errorf("failed to load the webhook TLS certificate: %v", err)
+	}
+
+	if err := installMutatingWebhookConfiguration(
+		ws.kubeClient, ws.config.ServiceName, ws.config.Namespace, cert.certPEM); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(mutatePath, ws.serveMutatePods)
+
+	ws.server = &http.Server{
+		Addr:      fmt.Sprintf(":%d", ws.config.Port),
+		Handler:   mux,
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{tlsCert}},
+	}
+
+	go func() {
+		if err := ws.server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+			glog.Errorf("webhook server stopped serving: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop shuts down the webhook server and removes the MutatingWebhookConfiguration.
+func (ws *WebhookServer) Stop() error {
+	if ws.server != nil {
+		if err := ws.server.Shutdown(context.Background()); err != nil {
+			return err
+		}
+	}
+	return uninstallMutatingWebhookConfiguration(ws.kubeClient)
+}
+
+func (ws *WebhookServer) serveMutatePods(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review := &admissionv1beta1.AdmissionReview{}
+	if _, _, err := universalDeserializer.Decode(body, nil, review); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := ws.mutate(review.Request)
+	review.Response = response
+
+	responseBytes, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(responseBytes)
+}
+
+func (ws *WebhookServer) mutate(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	allowed := &admissionv1beta1.AdmissionResponse{Allowed: true, UID: request.UID}
+
+	pod := &apiv1.Pod{}
+	if err := json.Unmarshal(request.Object.Raw, pod); err != nil {
+		return admissionError(request.UID, err)
+	}
+
+	role, ok := pod.Labels[sparkRoleLabel]
+	if !ok {
+		// Not a Spark driver or executor pod; let it through unmodified.
+		return allowed
+	}
+
+	appID, ok := pod.Labels[SparkAppIDLabel]
+	if !ok {
+		return allowed
+	}
+
+	app, err := ws.getSparkApplication(pod.Namespace, appID)
+	if err != nil {
+		glog.Warningf("failed to look up SparkApplication with app ID %s for pod %s: %v", appID, pod.Name, err)
+		return allowed
+	}
+
+	patchOps := buildPatch(pod, role, app)
+	if len(patchOps) == 0 {
+		return allowed
+	}
+
+	patchBytes, err := json.Marshal(patchOps)
+	if err != nil {
+		return admissionError(request.UID, err)
+	}
+
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	allowed.Patch = patchBytes
+	allowed.PatchType = &patchType
+	return allowed
+}
+
+// getSparkApplication finds the SparkApplication in namespace that was submitted with the given
+// app ID, by scanning the namespace -- there's no index from app ID back to the owning
+// SparkApplication name, so this relies on the namespace's SparkApplication count staying small.
+func (ws *WebhookServer) getSparkApplication(namespace, appID string) (*v1alpha1.SparkApplication, error) {
+	apps, err := ws.crdClient.SparkoperatorV1alpha1().SparkApplications(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range apps.Items {
+		if apps.Items[i].Status.AppID == appID {
+			return &apps.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no SparkApplication with app ID %s found in namespace %s", appID, namespace)
+}
+
+func admissionError(uid types.UID, err error) *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{
+		UID:     uid,
+		Allowed: false,
+		Result:  &metav1.Status{Message: err.Error()},
+	}
+}